@@ -0,0 +1,46 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package analysis provides pre-flight diagnostics for eventing resources,
+// in the spirit of istioctl's "precheck" analyzers: small, independent
+// checks that fan out over a Snapshot of the cluster and surface actionable
+// Messages instead of letting callers time out waiting on a raw Ready
+// condition.
+package analysis
+
+import (
+	"context"
+
+	"github.com/knative/eventing/test"
+)
+
+// Snapshot is the set of inputs an Analyzer needs: the clients to query and
+// the namespace to inspect. The same Snapshot is handed to every registered
+// Analyzer; it holds no listed objects, so each Analyzer lists what it needs.
+type Snapshot struct {
+	Clients   *test.Clients
+	Namespace string
+}
+
+// Analyzer inspects a Snapshot and returns the Messages it finds. Analyzers
+// are expected to be side-effect free and safe to run concurrently with
+// other Analyzers over the same Snapshot.
+type Analyzer interface {
+	// Name identifies the Analyzer. Messages are not sorted by it: Registry.Run
+	// returns them in registration order.
+	Name() string
+	Analyze(ctx context.Context, snapshot *Snapshot) []Message
+}
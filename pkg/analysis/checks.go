@@ -0,0 +1,219 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	DefaultRegistry.Register(&ClusterProvisionerAnalyzer{})
+	DefaultRegistry.Register(&SubscriptionAnalyzer{})
+	DefaultRegistry.Register(&FlowAnalyzer{})
+	DefaultRegistry.Register(&RBACAnalyzer{})
+}
+
+// ClusterProvisionerAnalyzer checks that every ClusterProvisioner referenced
+// by a Channel in the namespace exists and is ready, using
+// ClusterProvisionerStatus.IsReady() as exercised by
+// cluster_provisioner_types_test.go.
+type ClusterProvisionerAnalyzer struct{}
+
+func (*ClusterProvisionerAnalyzer) Name() string { return "ClusterProvisionerAnalyzer" }
+
+func (a *ClusterProvisionerAnalyzer) Analyze(ctx context.Context, snapshot *Snapshot) []Message {
+	var messages []Message
+	channels, err := snapshot.Clients.Eventing.ChannelsV1alpha1().Channels(snapshot.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return []Message{{Severity: Error, Kind: "Channel", Namespace: snapshot.Namespace, Reason: fmt.Sprintf("listing channels: %v", err)}}
+	}
+	for _, ch := range channels.Items {
+		if ch.Spec.Provisioner == nil || ch.Spec.Provisioner.Ref == nil {
+			// Channel is backed by a ClusterBus (see CreateClusterBus in
+			// test/e2e/e2e.go) rather than a ClusterProvisioner; nothing for
+			// this analyzer to check.
+			continue
+		}
+		name := ch.Spec.Provisioner.Ref.Name
+		cp, err := snapshot.Clients.Eventing.EventingV1alpha1().ClusterProvisioners().Get(name, metav1.GetOptions{})
+		if err != nil {
+			messages = append(messages, Message{
+				Severity:  Error,
+				Kind:      "Channel",
+				Namespace: ch.Namespace,
+				Name:      ch.Name,
+				Reason:    fmt.Sprintf("referenced ClusterProvisioner %q does not exist: %v", name, err),
+			})
+			continue
+		}
+		if !cp.Status.IsReady() {
+			messages = append(messages, Message{
+				Severity:  Error,
+				Kind:      "Channel",
+				Namespace: ch.Namespace,
+				Name:      ch.Name,
+				Reason:    fmt.Sprintf("ClusterProvisioner %q is not ready", name),
+			})
+		}
+	}
+	return messages
+}
+
+// SubscriptionAnalyzer checks that every Subscription points at a Channel
+// that exists and whose provisioner is ready.
+type SubscriptionAnalyzer struct{}
+
+func (*SubscriptionAnalyzer) Name() string { return "SubscriptionAnalyzer" }
+
+func (a *SubscriptionAnalyzer) Analyze(ctx context.Context, snapshot *Snapshot) []Message {
+	var messages []Message
+	subs, err := snapshot.Clients.Eventing.ChannelsV1alpha1().Subscriptions(snapshot.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return []Message{{Severity: Error, Kind: "Subscription", Namespace: snapshot.Namespace, Reason: fmt.Sprintf("listing subscriptions: %v", err)}}
+	}
+	for _, sub := range subs.Items {
+		channelName := sub.Spec.Channel
+		ch, err := snapshot.Clients.Eventing.ChannelsV1alpha1().Channels(sub.Namespace).Get(channelName, metav1.GetOptions{})
+		if err != nil {
+			messages = append(messages, Message{
+				Severity:  Error,
+				Kind:      "Subscription",
+				Namespace: sub.Namespace,
+				Name:      sub.Name,
+				Reason:    fmt.Sprintf("referenced Channel %q does not exist: %v", channelName, err),
+			})
+			continue
+		}
+		if ch.Spec.Provisioner == nil || ch.Spec.Provisioner.Ref == nil {
+			// Channel is backed by a ClusterBus rather than a
+			// ClusterProvisioner; nothing for this analyzer to check.
+			continue
+		}
+		provisioner := ch.Spec.Provisioner.Ref.Name
+		cp, err := snapshot.Clients.Eventing.EventingV1alpha1().ClusterProvisioners().Get(provisioner, metav1.GetOptions{})
+		if err != nil || !cp.Status.IsReady() {
+			messages = append(messages, Message{
+				Severity:  Warning,
+				Kind:      "Subscription",
+				Namespace: sub.Namespace,
+				Name:      sub.Name,
+				Reason:    fmt.Sprintf("Channel %q's ClusterProvisioner %q is not ready", channelName, provisioner),
+			})
+		}
+	}
+	return messages
+}
+
+// FlowAnalyzer checks that every Flow targets a reachable Route/Service and a
+// registered EventType/EventSource.
+type FlowAnalyzer struct{}
+
+func (*FlowAnalyzer) Name() string { return "FlowAnalyzer" }
+
+func (a *FlowAnalyzer) Analyze(ctx context.Context, snapshot *Snapshot) []Message {
+	var messages []Message
+	flows, err := snapshot.Clients.Eventing.FlowsV1alpha1().Flows(snapshot.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return []Message{{Severity: Error, Kind: "Flow", Namespace: snapshot.Namespace, Reason: fmt.Sprintf("listing flows: %v", err)}}
+	}
+	for _, flow := range flows.Items {
+		if flow.Spec.Action.RouteName != "" {
+			if _, err := snapshot.Clients.Serving.ServingV1alpha1().Routes(flow.Namespace).Get(flow.Spec.Action.RouteName, metav1.GetOptions{}); err != nil {
+				messages = append(messages, Message{
+					Severity:  Error,
+					Kind:      "Flow",
+					Namespace: flow.Namespace,
+					Name:      flow.Name,
+					Reason:    fmt.Sprintf("target Route %q is not reachable: %v", flow.Spec.Action.RouteName, err),
+				})
+			}
+		}
+		if _, err := snapshot.Clients.Eventing.FeedsV1alpha1().EventTypes(flow.Namespace).Get(flow.Spec.Trigger.EventType, metav1.GetOptions{}); err != nil {
+			messages = append(messages, Message{
+				Severity:  Error,
+				Kind:      "Flow",
+				Namespace: flow.Namespace,
+				Name:      flow.Name,
+				Reason:    fmt.Sprintf("EventType %q is not registered: %v", flow.Spec.Trigger.EventType, err),
+			})
+		}
+		if flow.Spec.Trigger.Resource != "" {
+			if _, err := snapshot.Clients.Eventing.FeedsV1alpha1().EventSources(flow.Namespace).Get(flow.Spec.Trigger.Service, metav1.GetOptions{}); err != nil {
+				messages = append(messages, Message{
+					Severity:  Error,
+					Kind:      "Flow",
+					Namespace: flow.Namespace,
+					Name:      flow.Name,
+					Reason:    fmt.Sprintf("EventSource %q is not registered: %v", flow.Spec.Trigger.Service, err),
+				})
+			}
+		}
+	}
+	return messages
+}
+
+// RBACAnalyzer checks that the ServiceAccount created by
+// CreateServiceAccountAndBinding actually has the permissions required by
+// the Feeds that reference it, via a SubjectAccessReview for each of the
+// same fixed verbs (get, list, watch, create, delete) on the feed's trigger
+// resource; the verbs are not derived per feed. This must be a SubjectAccessReview, not a
+// SelfSubjectAccessReview: the latter has no field to name a different
+// principal and would only ever evaluate the test client's own (usually
+// cluster-admin) credentials, silently reporting "Allowed" regardless of
+// what the feed's ServiceAccount can actually do.
+type RBACAnalyzer struct{}
+
+func (*RBACAnalyzer) Name() string { return "RBACAnalyzer" }
+
+func (a *RBACAnalyzer) Analyze(ctx context.Context, snapshot *Snapshot) []Message {
+	var messages []Message
+	feeds, err := snapshot.Clients.Eventing.FeedsV1alpha1().Feeds(snapshot.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return []Message{{Severity: Error, Kind: "Feed", Namespace: snapshot.Namespace, Reason: fmt.Sprintf("listing feeds: %v", err)}}
+	}
+	for _, feed := range feeds.Items {
+		sa := feed.Spec.ServiceAccountName
+		user := fmt.Sprintf("system:serviceaccount:%s:%s", feed.Namespace, sa)
+		for _, verb := range []string{"get", "list", "watch", "create", "delete"} {
+			review := &authv1.SubjectAccessReview{
+				Spec: authv1.SubjectAccessReviewSpec{
+					User: user,
+					ResourceAttributes: &authv1.ResourceAttributes{
+						Namespace: feed.Namespace,
+						Verb:      verb,
+						Resource:  feed.Spec.Trigger.Resource,
+					},
+				},
+			}
+			res, err := snapshot.Clients.Kube.Kube.AuthorizationV1().SubjectAccessReviews().Create(review)
+			if err != nil || !res.Status.Allowed {
+				messages = append(messages, Message{
+					Severity:  Error,
+					Kind:      "Feed",
+					Namespace: feed.Namespace,
+					Name:      feed.Name,
+					Reason:    fmt.Sprintf("ServiceAccount %q is not allowed to %q %q", sa, verb, feed.Spec.Trigger.Resource),
+				})
+			}
+		}
+	}
+	return messages
+}
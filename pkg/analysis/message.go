@@ -0,0 +1,49 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import "fmt"
+
+// Severity classifies how urgently a Message should block an e2e scenario
+// (or a user's cluster) from proceeding.
+type Severity string
+
+const (
+	// Info messages are informational only; they never fail a preflight run.
+	Info Severity = "Info"
+	// Warning messages indicate a likely misconfiguration that may cause
+	// flakiness or partial functionality, but isn't certain to fail.
+	Warning Severity = "Warning"
+	// Error messages indicate the analyzed object cannot possibly work, e.g.
+	// a Channel whose ClusterProvisioner doesn't exist.
+	Error Severity = "Error"
+)
+
+// Message is a single finding produced by an Analyzer. It identifies the
+// object the finding is about so that callers (test authors, or eventually
+// a CLI) can report it without re-deriving context.
+type Message struct {
+	Severity  Severity
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+}
+
+func (m Message) String() string {
+	return fmt.Sprintf("[%s] %s %s/%s: %s", m.Severity, m.Kind, m.Namespace, m.Name, m.Reason)
+}
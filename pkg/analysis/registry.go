@@ -0,0 +1,52 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import "context"
+
+// Registry holds the set of Analyzers that should run during a preflight
+// pass. A package-level DefaultRegistry covers the built-in checks in this
+// package; operators embedding these checks in a CLI subcommand can build
+// their own Registry to add or omit analyzers.
+type Registry struct {
+	analyzers []Analyzer
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds an Analyzer to the Registry. It is typically called from an
+// init() function of the package that owns the Analyzer.
+func (r *Registry) Register(a Analyzer) {
+	r.analyzers = append(r.analyzers, a)
+}
+
+// Run executes every registered Analyzer against snapshot and returns the
+// concatenation of their Messages, in registration order.
+func (r *Registry) Run(ctx context.Context, snapshot *Snapshot) []Message {
+	var messages []Message
+	for _, a := range r.analyzers {
+		messages = append(messages, a.Analyze(ctx, snapshot)...)
+	}
+	return messages
+}
+
+// DefaultRegistry is the Registry used by test/e2e's RunPreflight helper. The
+// built-in analyzers in this package register themselves here.
+var DefaultRegistry = NewRegistry()
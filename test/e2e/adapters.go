@@ -0,0 +1,148 @@
+package e2e
+
+import (
+	feedsV1alpha1 "github.com/knative/eventing/pkg/apis/feeds/v1alpha1"
+	"github.com/knative/eventing/test"
+	corev1 "k8s.io/api/core/v1"
+	rbacV1beta1 "k8s.io/api/rbac/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// feedsClient is the interface that backs eventSourceDeleter/eventTypeDeleter
+// below. It has the same method set as FeedClient, so any FeedClient can be
+// wrapped by them.
+type feedsClient interface {
+	CreateEventSource(*feedsV1alpha1.EventSource) (*feedsV1alpha1.EventSource, error)
+	DeleteEventSource(name string, options *metav1.DeleteOptions) error
+	CreateEventType(*feedsV1alpha1.EventType) (*feedsV1alpha1.EventType, error)
+	DeleteEventType(name string, options *metav1.DeleteOptions) error
+}
+
+// eventSourceDeleter and eventTypeDeleter adapt a FeedClient's two
+// differently-named Delete methods to the plain Delete(name, options) shape
+// test.Cleaner expects, so CreateEventSource/CreateEventType can register
+// cleanup without test.Cleaner knowing about FeedClient at all.
+type eventSourceDeleter struct{ feeds feedsClient }
+
+func (d eventSourceDeleter) Delete(name string, options *metav1.DeleteOptions) error {
+	return d.feeds.DeleteEventSource(name, options)
+}
+
+type eventTypeDeleter struct{ feeds feedsClient }
+
+func (d eventTypeDeleter) Delete(name string, options *metav1.DeleteOptions) error {
+	return d.feeds.DeleteEventType(name, options)
+}
+
+// feedClientAdapter adapts the generated FeedsV1alpha1 EventSources(ns) and
+// EventTypes(ns) clients, which expose plain Create/Delete each, to the
+// single bundled FeedClient interface CreateEventSource/CreateEventType take.
+type feedClientAdapter struct {
+	clients   *test.Clients
+	namespace string
+}
+
+// NewFeedClient adapts clients' typed EventSource/EventType clients for
+// namespace to FeedClient.
+func NewFeedClient(clients *test.Clients, namespace string) FeedClient {
+	return feedClientAdapter{clients: clients, namespace: namespace}
+}
+
+func (f feedClientAdapter) CreateEventSource(es *feedsV1alpha1.EventSource) (*feedsV1alpha1.EventSource, error) {
+	return f.clients.Eventing.FeedsV1alpha1().EventSources(f.namespace).Create(es)
+}
+
+func (f feedClientAdapter) DeleteEventSource(name string, options *metav1.DeleteOptions) error {
+	return f.clients.Eventing.FeedsV1alpha1().EventSources(f.namespace).Delete(name, options)
+}
+
+func (f feedClientAdapter) CreateEventType(et *feedsV1alpha1.EventType) (*feedsV1alpha1.EventType, error) {
+	return f.clients.Eventing.FeedsV1alpha1().EventTypes(f.namespace).Create(et)
+}
+
+func (f feedClientAdapter) DeleteEventType(name string, options *metav1.DeleteOptions) error {
+	return f.clients.Eventing.FeedsV1alpha1().EventTypes(f.namespace).Delete(name, options)
+}
+
+// podClientAdapter adapts the generated corev1 Pod client to PodClient,
+// collapsing GetLogs(...).Do() into a single call since WaitForLogContent
+// only ever wants the raw bytes.
+type podClientAdapter struct {
+	pods corev1client.PodInterface
+}
+
+// NewPodClient adapts clients' typed Pod client for namespace to PodClient.
+func NewPodClient(clients *test.Clients, namespace string) PodClient {
+	return podClientAdapter{clients.Kube.Kube.CoreV1().Pods(namespace)}
+}
+
+func (p podClientAdapter) Create(pod *corev1.Pod) (*corev1.Pod, error) {
+	return p.pods.Create(pod)
+}
+
+func (p podClientAdapter) Delete(name string, options *metav1.DeleteOptions) error {
+	return p.pods.Delete(name, options)
+}
+
+func (p podClientAdapter) List(opts metav1.ListOptions) (*corev1.PodList, error) {
+	return p.pods.List(opts)
+}
+
+func (p podClientAdapter) GetLogs(name string, opts *corev1.PodLogOptions) RawResult {
+	return p.pods.GetLogs(name, opts).Do()
+}
+
+// rbacClientAdapter adapts the generated core and rbac clients to RBACClient.
+type rbacClientAdapter struct {
+	clients   *test.Clients
+	namespace string
+}
+
+// NewRBACClient adapts clients' typed ServiceAccount and ClusterRoleBinding
+// clients for namespace to RBACClient.
+func NewRBACClient(clients *test.Clients, namespace string) RBACClient {
+	return rbacClientAdapter{clients: clients, namespace: namespace}
+}
+
+func (r rbacClientAdapter) CreateServiceAccount(sa *corev1.ServiceAccount) (*corev1.ServiceAccount, error) {
+	return r.clients.Kube.Kube.CoreV1().ServiceAccounts(r.namespace).Create(sa)
+}
+
+func (r rbacClientAdapter) DeleteServiceAccount(name string, options *metav1.DeleteOptions) error {
+	return r.clients.Kube.Kube.CoreV1().ServiceAccounts(r.namespace).Delete(name, options)
+}
+
+func (r rbacClientAdapter) CreateClusterRoleBinding(crb *rbacV1beta1.ClusterRoleBinding) (*rbacV1beta1.ClusterRoleBinding, error) {
+	return r.clients.Kube.Kube.RbacV1beta1().ClusterRoleBindings().Create(crb)
+}
+
+func (r rbacClientAdapter) DeleteClusterRoleBinding(name string, options *metav1.DeleteOptions) error {
+	return r.clients.Kube.Kube.RbacV1beta1().ClusterRoleBindings().Delete(name, options)
+}
+
+// rbacClient is the interface that backs
+// serviceAccountDeleter/clusterRoleBindingDeleter below. It has the same
+// method set as RBACClient, so any RBACClient can be wrapped by them.
+type rbacClient interface {
+	CreateServiceAccount(*corev1.ServiceAccount) (*corev1.ServiceAccount, error)
+	DeleteServiceAccount(name string, options *metav1.DeleteOptions) error
+	CreateClusterRoleBinding(*rbacV1beta1.ClusterRoleBinding) (*rbacV1beta1.ClusterRoleBinding, error)
+	DeleteClusterRoleBinding(name string, options *metav1.DeleteOptions) error
+}
+
+// serviceAccountDeleter and clusterRoleBindingDeleter adapt an RBACClient's
+// two differently-named Delete methods to the plain Delete(name, options)
+// shape test.Cleaner expects, the same way eventSourceDeleter/
+// eventTypeDeleter do for FeedClient.
+type serviceAccountDeleter struct{ rbac rbacClient }
+
+func (d serviceAccountDeleter) Delete(name string, options *metav1.DeleteOptions) error {
+	return d.rbac.DeleteServiceAccount(name, options)
+}
+
+type clusterRoleBindingDeleter struct{ rbac rbacClient }
+
+func (d clusterRoleBindingDeleter) Delete(name string, options *metav1.DeleteOptions) error {
+	return d.rbac.DeleteClusterRoleBinding(name, options)
+}
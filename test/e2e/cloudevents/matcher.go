@@ -0,0 +1,133 @@
+// Package cloudevents provides e2e assertion helpers that understand the
+// CloudEvents envelope delivered through a Flow/Channel/Subscription,
+// instead of treating delivery as opaque log lines.
+package cloudevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Event is the subset of the CloudEvents 0.2/1.0 envelope these matchers
+// care about. Both versions are accepted on read: 0.2 carries the spec
+// version as "specversion": "0.2" with "contenttype"/"source", 1.0 uses
+// "type" unchanged and unifies some field names, which is why parsing goes
+// through a tolerant map rather than two distinct structs.
+type Event struct {
+	Type       string
+	Source     string
+	ID         string
+	Extensions map[string]string
+	Data       []byte
+}
+
+// EventMatcher reports whether an Event satisfies some condition. Matchers
+// compose via And/Or so tests can build up assertions like
+// And(MatchType("dev.knative.foo"), MatchSource("my-source")).
+type EventMatcher func(e Event) error
+
+// MatchType requires the event's type to equal want exactly.
+func MatchType(want string) EventMatcher {
+	return func(e Event) error {
+		if e.Type != want {
+			return fmt.Errorf("event type %q does not match %q", e.Type, want)
+		}
+		return nil
+	}
+}
+
+// MatchSource requires the event's source to equal want exactly.
+func MatchSource(want string) EventMatcher {
+	return func(e Event) error {
+		if e.Source != want {
+			return fmt.Errorf("event source %q does not match %q", e.Source, want)
+		}
+		return nil
+	}
+}
+
+// MatchExtension requires the event to carry an extension attribute named
+// key with the given value.
+func MatchExtension(key, want string) EventMatcher {
+	return func(e Event) error {
+		got, ok := e.Extensions[key]
+		if !ok {
+			return fmt.Errorf("event has no extension %q", key)
+		}
+		if got != want {
+			return fmt.Errorf("extension %q = %q, want %q", key, got, want)
+		}
+		return nil
+	}
+}
+
+// MatchDataJSONPath requires the event's data, parsed as JSON, to have a
+// value at the given dot-separated path equal to want, e.g. "items.0.name".
+// Numeric path segments index into JSON arrays.
+func MatchDataJSONPath(path, want string) EventMatcher {
+	return func(e Event) error {
+		var data interface{}
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return fmt.Errorf("event data is not valid JSON: %v", err)
+		}
+		got, err := lookupJSONPath(data, strings.Split(path, "."))
+		if err != nil {
+			return fmt.Errorf("event data has no value at path %q: %v", path, err)
+		}
+		gotStr := fmt.Sprintf("%v", got)
+		if gotStr != want {
+			return fmt.Errorf("event data at %q = %q, want %q", path, gotStr, want)
+		}
+		return nil
+	}
+}
+
+func lookupJSONPath(data interface{}, segments []string) (interface{}, error) {
+	if len(segments) == 0 {
+		return data, nil
+	}
+	segment, rest := segments[0], segments[1:]
+	switch v := data.(type) {
+	case map[string]interface{}:
+		next, ok := v[segment]
+		if !ok {
+			return nil, fmt.Errorf("no key %q", segment)
+		}
+		return lookupJSONPath(next, rest)
+	case []interface{}:
+		idx, err := strconv.Atoi(segment)
+		if err != nil || idx < 0 || idx >= len(v) {
+			return nil, fmt.Errorf("no index %q", segment)
+		}
+		return lookupJSONPath(v[idx], rest)
+	default:
+		return nil, fmt.Errorf("cannot index into %T with %q", data, segment)
+	}
+}
+
+// And succeeds only if every matcher succeeds, returning the first failure.
+func And(matchers ...EventMatcher) EventMatcher {
+	return func(e Event) error {
+		for _, m := range matchers {
+			if err := m(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Or succeeds if any matcher succeeds, returning the last failure if none do.
+func Or(matchers ...EventMatcher) EventMatcher {
+	return func(e Event) error {
+		var err error
+		for _, m := range matchers {
+			if err = m(e); err == nil {
+				return nil
+			}
+		}
+		return err
+	}
+}
@@ -0,0 +1,102 @@
+package cloudevents
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/knative/eventing/test/e2e"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	interval = 1 * time.Second
+	timeout  = 1 * time.Minute
+)
+
+// WaitForCloudEvent tails the receiver pod's stdout through pods (which
+// e2e.NewPodClient/fake.Clients.PodClient scope to a namespace, including a
+// Harness-allocated per-test one), parsing each line as a CloudEvents
+// 0.2/1.0 JSON envelope, until one line satisfies matcher or timeout
+// elapses. It returns the first matching Event, or the last match error
+// seen if no line ever satisfies matcher. The pod lookup itself is
+// e2e.PodLogs's, not reimplemented here.
+func WaitForCloudEvent(pods e2e.PodClient, podName, container string, matcher EventMatcher) (Event, error) {
+	var found Event
+	var lastErr error
+	err := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		logs, err := e2e.PodLogs(pods, podName, container, nil)
+		if err != nil {
+			return false, nil
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(logs))
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			event, err := parseEvent(line)
+			if err != nil {
+				continue
+			}
+			if err := matcher(event); err != nil {
+				lastErr = err
+				continue
+			}
+			found = event
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			return Event{}, fmt.Errorf("no CloudEvent matched before timeout, last mismatch: %v", lastErr)
+		}
+		return Event{}, err
+	}
+	return found, nil
+}
+
+// parseEvent tolerantly decodes a single JSON CloudEvents envelope, handling
+// both the 0.2 ("contenttype") and 1.0 ("datacontenttype") attribute names
+// and treating any other top-level string field as an extension attribute.
+func parseEvent(line []byte) (Event, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return Event{}, err
+	}
+
+	event := Event{Extensions: map[string]string{}}
+	known := map[string]bool{
+		"specversion": true, "contenttype": true, "datacontenttype": true, "data": true,
+	}
+	for key, value := range raw {
+		var s string
+		switch key {
+		case "type":
+			known[key] = true
+			json.Unmarshal(value, &event.Type)
+		case "source":
+			known[key] = true
+			json.Unmarshal(value, &event.Source)
+		case "id":
+			known[key] = true
+			json.Unmarshal(value, &event.ID)
+		case "data":
+			event.Data = value
+		default:
+			if known[key] {
+				continue
+			}
+			if err := json.Unmarshal(value, &s); err == nil {
+				event.Extensions[key] = s
+			}
+		}
+	}
+	if event.Type == "" {
+		return Event{}, fmt.Errorf("line does not look like a CloudEvent: %s", line)
+	}
+	return event, nil
+}
@@ -0,0 +1,73 @@
+package cloudevents
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    Event
+		wantErr bool
+	}{{
+		name: "1.0 envelope with extension",
+		line: `{"specversion":"1.0","type":"dev.knative.foo","source":"my-source","id":"abc","myext":"value","data":{"msg":"hi"}}`,
+		want: Event{
+			Type:       "dev.knative.foo",
+			Source:     "my-source",
+			ID:         "abc",
+			Extensions: map[string]string{"myext": "value"},
+			Data:       []byte(`{"msg":"hi"}`),
+		},
+	}, {
+		name: "0.2 envelope with contenttype",
+		line: `{"specversion":"0.2","type":"dev.knative.bar","source":"other-source","id":"xyz","contenttype":"application/json","data":{"msg":"bye"}}`,
+		want: Event{
+			Type:       "dev.knative.bar",
+			Source:     "other-source",
+			ID:         "xyz",
+			Extensions: map[string]string{},
+			Data:       []byte(`{"msg":"bye"}`),
+		},
+	}, {
+		name:    "not JSON",
+		line:    `this is a plain log line, not an event`,
+		wantErr: true,
+	}, {
+		name:    "JSON but no type attribute",
+		line:    `{"hello":"world"}`,
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseEvent([]byte(test.line))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("parseEvent(%q) = %v, want error", test.line, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseEvent(%q) returned unexpected error: %v", test.line, err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("unexpected event (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestMatchDataJSONPath(t *testing.T) {
+	event := Event{Data: []byte(`{"items":[{"name":"first"},{"name":"second"}]}`)}
+
+	if err := MatchDataJSONPath("items.1.name", "second")(event); err != nil {
+		t.Errorf("MatchDataJSONPath did not match existing path: %v", err)
+	}
+	if err := MatchDataJSONPath("items.5.name", "second")(event); err == nil {
+		t.Error("MatchDataJSONPath matched an out-of-range index, want error")
+	}
+}
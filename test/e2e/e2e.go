@@ -96,9 +96,10 @@ func WithRouteReady(clients *test.Clients, logger *logging.BaseLogger, cleaner *
 	return nil
 }
 
-// CreateFlow will create a Flow
-func CreateFlow(clients *test.Clients, flow *flowsV1alpha1.Flow, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
-	flows := clients.Eventing.FlowsV1alpha1().Flows(pkgTest.Flags.Namespace)
+// CreateFlow will create a Flow using the given FlowClient. Passing the
+// narrow FlowClient interface, rather than *test.Clients, is what lets
+// fake.Clients back this helper in controller and reconciliation tests.
+func CreateFlow(flows FlowClient, flow *flowsV1alpha1.Flow, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
 	res, err := flows.Create(flow)
 	if err != nil {
 		return err
@@ -109,20 +110,18 @@ func CreateFlow(clients *test.Clients, flow *flowsV1alpha1.Flow, logger *logging
 
 // WithFlowReady will create a Flow and wait until it is ready
 func WithFlowReady(clients *test.Clients, flow *flowsV1alpha1.Flow, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
-	err := CreateFlow(clients, flow, logger, cleaner)
-	if err != nil {
+	flows := clients.Eventing.FlowsV1alpha1().Flows(pkgTest.Flags.Namespace)
+	if err := CreateFlow(flows, flow, logger, cleaner); err != nil {
 		return err
 	}
-	flows := clients.Eventing.FlowsV1alpha1().Flows(pkgTest.Flags.Namespace)
 	if err := test.WaitForFlowState(flows, flow.ObjectMeta.Name, test.IsFlowReady, "FlowIsReady"); err != nil {
 		return err
 	}
 	return nil
 }
 
-// CreateChannel will create a Channel
-func CreateChannel(clients *test.Clients, channel *channelsV1alpha1.Channel, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
-	channels := clients.Eventing.ChannelsV1alpha1().Channels(pkgTest.Flags.Namespace)
+// CreateChannel will create a Channel using the given ChannelClient.
+func CreateChannel(channels ChannelClient, channel *channelsV1alpha1.Channel, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
 	res, err := channels.Create(channel)
 	if err != nil {
 		return err
@@ -131,9 +130,9 @@ func CreateChannel(clients *test.Clients, channel *channelsV1alpha1.Channel, log
 	return nil
 }
 
-// CreateSubscription will create a Subscription
-func CreateSubscription(clients *test.Clients, subs *channelsV1alpha1.Subscription, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
-	subscriptions := clients.Eventing.ChannelsV1alpha1().Subscriptions(pkgTest.Flags.Namespace)
+// CreateSubscription will create a Subscription using the given
+// SubscriptionClient.
+func CreateSubscription(subscriptions SubscriptionClient, subs *channelsV1alpha1.Subscription, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
 	res, err := subscriptions.Create(subs)
 	if err != nil {
 		return err
@@ -142,38 +141,39 @@ func CreateSubscription(clients *test.Clients, subs *channelsV1alpha1.Subscripti
 	return nil
 }
 
-// CreateServiceAccount will create a service account
-func CreateServiceAccount(clients *test.Clients, sa *corev1.ServiceAccount, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
-	sas := clients.Kube.Kube.CoreV1().ServiceAccounts(pkgTest.Flags.Namespace)
-	res, err := sas.Create(sa)
+// CreateServiceAccount will create a service account using the given
+// RBACClient.
+func CreateServiceAccount(rbac RBACClient, sa *corev1.ServiceAccount, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
+	res, err := rbac.CreateServiceAccount(sa)
 	if err != nil {
 		return err
 	}
-	cleaner.Add(sas, res.ObjectMeta.Name)
+	cleaner.Add(serviceAccountDeleter{rbac}, res.ObjectMeta.Name)
 	return nil
 }
 
-// CreateClusterRoleBinding will create a service account binding
-func CreateClusterRoleBinding(clients *test.Clients, crb *rbacV1beta1.ClusterRoleBinding, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
-	clusterRoleBindings := clients.Kube.Kube.RbacV1beta1().ClusterRoleBindings()
-	res, err := clusterRoleBindings.Create(crb)
+// CreateClusterRoleBinding will create a service account binding using the
+// given RBACClient.
+func CreateClusterRoleBinding(rbac RBACClient, crb *rbacV1beta1.ClusterRoleBinding, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
+	res, err := rbac.CreateClusterRoleBinding(crb)
 	if err != nil {
 		return err
 	}
-	cleaner.Add(clusterRoleBindings, res.ObjectMeta.Name)
+	cleaner.Add(clusterRoleBindingDeleter{rbac}, res.ObjectMeta.Name)
 	return nil
 }
 
 // CreateServiceAccountAndBinding creates both ServiceAccount and ClusterRoleBinding with default
 // cluster-admin role
 func CreateServiceAccountAndBinding(clients *test.Clients, name string, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
+	rbac := NewRBACClient(clients, defaultNamespaceName)
 	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: defaultNamespaceName,
 		},
 	}
-	err := CreateServiceAccount(clients, sa, logger, cleaner)
+	err := CreateServiceAccount(rbac, sa, logger, cleaner)
 	if err != nil {
 		return err
 	}
@@ -194,7 +194,7 @@ func CreateServiceAccountAndBinding(clients *test.Clients, name string, logger *
 			APIGroup: "rbac.authorization.k8s.io",
 		},
 	}
-	err = CreateClusterRoleBinding(clients, crb, logger, cleaner)
+	err = CreateClusterRoleBinding(rbac, crb, logger, cleaner)
 	if err != nil {
 		return err
 	}
@@ -212,31 +212,28 @@ func CreateClusterBus(clients *test.Clients, cbus *channelsV1alpha1.ClusterBus,
 	return nil
 }
 
-// CreateEventSource will create an EventSource
-func CreateEventSource(clients *test.Clients, es *feedsV1alpha1.EventSource, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
-	esources := clients.Eventing.FeedsV1alpha1().EventSources(pkgTest.Flags.Namespace)
-	res, err := esources.Create(es)
+// CreateEventSource will create an EventSource using the given FeedClient.
+func CreateEventSource(feeds FeedClient, es *feedsV1alpha1.EventSource, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
+	res, err := feeds.CreateEventSource(es)
 	if err != nil {
 		return err
 	}
-	cleaner.Add(esources, res.ObjectMeta.Name)
+	cleaner.Add(eventSourceDeleter{feeds}, res.ObjectMeta.Name)
 	return nil
 }
 
-// CreateEventType will create an EventType
-func CreateEventType(clients *test.Clients, et *feedsV1alpha1.EventType, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
-	eTypes := clients.Eventing.FeedsV1alpha1().EventTypes(pkgTest.Flags.Namespace)
-	res, err := eTypes.Create(et)
+// CreateEventType will create an EventType using the given FeedClient.
+func CreateEventType(feeds FeedClient, et *feedsV1alpha1.EventType, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
+	res, err := feeds.CreateEventType(et)
 	if err != nil {
 		return err
 	}
-	cleaner.Add(eTypes, res.ObjectMeta.Name)
+	cleaner.Add(eventTypeDeleter{feeds}, res.ObjectMeta.Name)
 	return nil
 }
 
-// CreatePod will create a Pod
-func CreatePod(clients *test.Clients, pod *corev1.Pod, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
-	pods := clients.Kube.Kube.CoreV1().Pods(pod.GetNamespace())
+// CreatePod will create a Pod using the given PodClient.
+func CreatePod(pods PodClient, pod *corev1.Pod, logger *logging.BaseLogger, cleaner *test.Cleaner) error {
 	res, err := pods.Create(pod)
 	if err != nil {
 		return err
@@ -246,18 +243,16 @@ func CreatePod(clients *test.Clients, pod *corev1.Pod, logger *logging.BaseLogge
 }
 
 // PodLogs returns Pod logs for given Pod and Container
-func PodLogs(clients *test.Clients, podName string, containerName string, logger *logging.BaseLogger) ([]byte, error) {
-	pods := clients.Kube.Kube.CoreV1().Pods(pkgTest.Flags.Namespace)
+func PodLogs(pods PodClient, podName string, containerName string, logger *logging.BaseLogger) ([]byte, error) {
 	podList, err := pods.List(metav1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 	for _, pod := range podList.Items {
 		if strings.Contains(pod.Name, podName) {
-			result := pods.GetLogs(pod.Name, &corev1.PodLogOptions{
+			return pods.GetLogs(pod.Name, &corev1.PodLogOptions{
 				Container: containerName,
-			}).Do()
-			return result.Raw()
+			}).Raw()
 		}
 	}
 	return nil, fmt.Errorf("Could not find logs for %s/%s", podName, containerName)
@@ -265,9 +260,9 @@ func PodLogs(clients *test.Clients, podName string, containerName string, logger
 
 // WaitForLogContent waits until logs for given Pod/Container include the given content.
 // If the content is not present within timeout it returns error.
-func WaitForLogContent(clients *test.Clients, logger *logging.BaseLogger, podName string, containerName string, content string) error {
+func WaitForLogContent(pods PodClient, logger *logging.BaseLogger, podName string, containerName string, content string) error {
 	return wait.PollImmediate(interval, timeout, func() (bool, error) {
-		logs, err := PodLogs(clients, podName, containerName, logger)
+		logs, err := PodLogs(pods, podName, containerName, logger)
 		if err != nil {
 			return true, err
 		}
@@ -0,0 +1,79 @@
+package fake
+
+import (
+	feedsV1alpha1 "github.com/knative/eventing/pkg/apis/feeds/v1alpha1"
+	eventingfake "github.com/knative/eventing/pkg/client/clientset/versioned/fake"
+	"github.com/knative/eventing/test/e2e"
+	corev1 "k8s.io/api/core/v1"
+	rbacV1beta1 "k8s.io/api/rbac/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// podClient satisfies e2e.PodClient against a fake Pod clientset. The real
+// podClientAdapter in test/e2e/adapters.go collapses GetLogs(...).Do() the
+// same way so both backends look identical to WaitForLogContent.
+type podClient struct {
+	pods corev1client.PodInterface
+}
+
+func (p podClient) Create(pod *corev1.Pod) (*corev1.Pod, error) {
+	return p.pods.Create(pod)
+}
+
+func (p podClient) Delete(name string, options *metav1.DeleteOptions) error {
+	return p.pods.Delete(name, options)
+}
+
+func (p podClient) List(opts metav1.ListOptions) (*corev1.PodList, error) {
+	return p.pods.List(opts)
+}
+
+func (p podClient) GetLogs(name string, opts *corev1.PodLogOptions) e2e.RawResult {
+	return p.pods.GetLogs(name, opts).Do()
+}
+
+// rbacClient satisfies e2e.RBACClient against a fake Kube clientset.
+type rbacClient struct {
+	kube      *kubefake.Clientset
+	namespace string
+}
+
+func (r rbacClient) CreateServiceAccount(sa *corev1.ServiceAccount) (*corev1.ServiceAccount, error) {
+	return r.kube.CoreV1().ServiceAccounts(r.namespace).Create(sa)
+}
+
+func (r rbacClient) DeleteServiceAccount(name string, options *metav1.DeleteOptions) error {
+	return r.kube.CoreV1().ServiceAccounts(r.namespace).Delete(name, options)
+}
+
+func (r rbacClient) CreateClusterRoleBinding(crb *rbacV1beta1.ClusterRoleBinding) (*rbacV1beta1.ClusterRoleBinding, error) {
+	return r.kube.RbacV1beta1().ClusterRoleBindings().Create(crb)
+}
+
+func (r rbacClient) DeleteClusterRoleBinding(name string, options *metav1.DeleteOptions) error {
+	return r.kube.RbacV1beta1().ClusterRoleBindings().Delete(name, options)
+}
+
+// feedClient satisfies e2e.FeedClient against a fake eventing clientset.
+type feedClient struct {
+	eventing  *eventingfake.Clientset
+	namespace string
+}
+
+func (f feedClient) CreateEventSource(es *feedsV1alpha1.EventSource) (*feedsV1alpha1.EventSource, error) {
+	return f.eventing.FeedsV1alpha1().EventSources(f.namespace).Create(es)
+}
+
+func (f feedClient) DeleteEventSource(name string, options *metav1.DeleteOptions) error {
+	return f.eventing.FeedsV1alpha1().EventSources(f.namespace).Delete(name, options)
+}
+
+func (f feedClient) CreateEventType(et *feedsV1alpha1.EventType) (*feedsV1alpha1.EventType, error) {
+	return f.eventing.FeedsV1alpha1().EventTypes(f.namespace).Create(et)
+}
+
+func (f feedClient) DeleteEventType(name string, options *metav1.DeleteOptions) error {
+	return f.eventing.FeedsV1alpha1().EventTypes(f.namespace).Delete(name, options)
+}
@@ -0,0 +1,48 @@
+// Package fake backs the test/e2e Create*/PodLogs/WaitForLogContent helpers
+// with client-go/testing.Fake-based clientsets instead of a live cluster, so
+// controller and reconciliation tests (and test.Cleaner itself) can reuse
+// them under `go test` without a kubeconfig.
+package fake
+
+import (
+	eventingfake "github.com/knative/eventing/pkg/client/clientset/versioned/fake"
+	"github.com/knative/eventing/test/e2e"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+// Clients bundles the fake clientsets used across the e2e helpers. It
+// mirrors *test.Clients' shape closely enough that the e2e.FlowClient,
+// e2e.ChannelClient, e2e.SubscriptionClient, e2e.FeedClient, e2e.PodClient
+// and e2e.RBACClient interfaces are all satisfied by namespacing into it the
+// same way production code namespaces into *test.Clients.
+type Clients struct {
+	Eventing *eventingfake.Clientset
+	Kube     *kubefake.Clientset
+}
+
+// NewClients returns a Clients whose fake clientsets start out empty. Tests
+// typically follow with a sequence of Create calls through the e2e helpers
+// to populate the state they want to assert against.
+func NewClients() *Clients {
+	return &Clients{
+		Eventing: eventingfake.NewSimpleClientset(),
+		Kube:     kubefake.NewSimpleClientset(),
+	}
+}
+
+// PodClient adapts c's fake Pod client for namespace to e2e.PodClient.
+func (c *Clients) PodClient(namespace string) e2e.PodClient {
+	return podClient{c.Kube.CoreV1().Pods(namespace)}
+}
+
+// RBACClient adapts c's fake core/rbac clients for namespace to
+// e2e.RBACClient.
+func (c *Clients) RBACClient(namespace string) e2e.RBACClient {
+	return rbacClient{kube: c.Kube, namespace: namespace}
+}
+
+// FeedClient adapts c's fake EventSource/EventType clients for namespace to
+// e2e.FeedClient.
+func (c *Clients) FeedClient(namespace string) e2e.FeedClient {
+	return feedClient{eventing: c.Eventing, namespace: namespace}
+}
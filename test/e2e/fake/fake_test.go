@@ -0,0 +1,43 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/knative/eventing/test/e2e"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// These compile-time assertions are the test: if Clients' adapters (or the
+// generated fake clientset's own namespaced interfaces, for FlowClient/
+// ChannelClient/SubscriptionClient, which need no adapter at all) ever drift
+// from the e2e.*Client interfaces, the package fails to build instead of
+// failing a test that happens to only exercise a few of their methods.
+var (
+	_ e2e.PodClient          = podClient{}
+	_ e2e.RBACClient         = rbacClient{}
+	_ e2e.FeedClient         = feedClient{}
+	_ e2e.FlowClient         = NewClients().Eventing.FlowsV1alpha1().Flows("ns")
+	_ e2e.ChannelClient      = NewClients().Eventing.ChannelsV1alpha1().Channels("ns")
+	_ e2e.SubscriptionClient = NewClients().Eventing.ChannelsV1alpha1().Subscriptions("ns")
+)
+
+func TestClientsSatisfyInterfaces(t *testing.T) {
+	c := NewClients()
+
+	var _ e2e.PodClient = c.PodClient("ns")
+	var _ e2e.RBACClient = c.RBACClient("ns")
+	var _ e2e.FeedClient = c.FeedClient("ns")
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "my-sa", Namespace: "ns"}}
+	if _, err := c.RBACClient("ns").CreateServiceAccount(sa); err != nil {
+		t.Fatalf("CreateServiceAccount: %v", err)
+	}
+	got, err := c.Kube.CoreV1().ServiceAccounts("ns").Get("my-sa", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("ServiceAccounts.Get after CreateServiceAccount: %v", err)
+	}
+	if got.Name != "my-sa" {
+		t.Errorf("got ServiceAccount %q, want %q", got.Name, "my-sa")
+	}
+}
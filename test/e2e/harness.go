@@ -0,0 +1,367 @@
+package e2e
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	channelsV1alpha1 "github.com/knative/eventing/pkg/apis/channels/v1alpha1"
+	flowsV1alpha1 "github.com/knative/eventing/pkg/apis/flows/v1alpha1"
+	"github.com/knative/eventing/test"
+	"github.com/knative/pkg/test/logging"
+	corev1 "k8s.io/api/core/v1"
+	rbacV1beta1 "k8s.io/api/rbac/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// istioDeleteGrace is the longest TearDown polls for VirtualServices to
+// disappear, replacing the old unconditional 20s sleep documented in #1376.
+// The poll runs only when the Harness recorded a VirtualService (see
+// CreateRouteAndConfig), so runs without one don't pay for the Istio churn.
+const istioDeleteGrace = 5 * time.Second
+
+// namespaceSuffix returns a value suitable for disambiguating namespace
+// names across concurrently-running test binaries. It draws from
+// crypto/rand (falling back to the process clock) rather than math/rand's
+// global source, which the Go versions this repo targets (see
+// test/test_images/eventrecorder's golang:1.10 base image) do not
+// auto-seed: every test binary would otherwise start from the same
+// deterministic sequence and compute the same "unique" namespace on its
+// first NewHarness call, which is exactly how this suite is run in CI —
+// each package's tests in their own process, often in parallel with other
+// packages. crypto/rand.Read is itself safe for concurrent use, so this
+// needs no locking of its own.
+func namespaceSuffix() int64 {
+	var buf [8]byte
+	if _, err := cryptorand.Read(buf[:]); err == nil {
+		return int64(binary.BigEndian.Uint64(buf[:]) & 0x7fffffffffffffff)
+	}
+	return time.Now().UnixNano()
+}
+
+// objectRef identifies a created object well enough to delete it later
+// without holding on to the whole typed object.
+type objectRef struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// node is one entry in the Harness's dependency DAG: an object, the refs it
+// depends on, and how to delete it.
+type node struct {
+	ref       objectRef
+	dependsOn []objectRef
+	delete    func() error
+}
+
+// HarnessOption configures a Harness at construction time.
+type HarnessOption func(*Harness)
+
+// WithTimeout overrides the Harness's default wait timeout (the package
+// constant `timeout`, 1 minute) for every Wait call made through it. Use
+// this for scenarios like CloudEvents delivery tests that legitimately need
+// longer than the default.
+func WithTimeout(d time.Duration) HarnessOption {
+	return func(h *Harness) { h.timeout = d }
+}
+
+// Harness gives each *testing.T its own namespace and records every object
+// it creates in a dependency DAG (Subscriptions depend on Channels; Flows
+// depend on their trigger's EventType and EventSource, while the Feed a Flow
+// leads to is not recorded; ClusterRoleBindings depend on ServiceAccounts) so TearDown can delete leaves first instead of firing
+// deletes in creation order and racing finalizers. Methods are safe to call
+// from a t.Parallel() test as long as each test uses its own Harness.
+type Harness struct {
+	T         *testing.T
+	Namespace string
+	Clients   *test.Clients
+	logger    *logging.BaseLogger
+	timeout   time.Duration
+
+	mu    sync.Mutex
+	nodes map[objectRef]*node
+}
+
+// NewHarness allocates a namespace unique to t and returns a Harness scoped
+// to it. Callers are responsible for calling TearDown (typically via
+// `defer`), mirroring the existing Setup/TearDown pair.
+func NewHarness(t *testing.T, clients *test.Clients, logger *logging.BaseLogger, opts ...HarnessOption) *Harness {
+	ns := fmt.Sprintf("e2e-%s-%d", sanitize(t.Name()), namespaceSuffix())
+	h := &Harness{
+		T:         t,
+		Namespace: ns,
+		Clients:   clients,
+		logger:    logger,
+		timeout:   timeout,
+		nodes:     map[objectRef]*node{},
+	}
+	if _, err := clients.Kube.Kube.CoreV1().Namespaces().Create(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: ns},
+	}); err != nil {
+		t.Fatalf("Couldn't create namespace %s: %v", ns, err)
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// sanitize lowercases name, replaces every character outside [a-z0-9] with
+// '-' and cuts it to 38 characters, so "e2e-<name>-<suffix>" is a valid
+// namespace name of at most 63 characters.
+func sanitize(name string) string {
+	out := make([]rune, 0, len(name))
+	for _, r := range name {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			r += 'a' - 'A'
+		case (r < 'a' || r > 'z') && (r < '0' || r > '9'):
+			r = '-'
+		}
+		out = append(out, r)
+	}
+	if len(out) > 38 {
+		out = out[:38]
+	}
+	return string(out)
+}
+
+func (h *Harness) record(n *node) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nodes[n.ref] = n
+}
+
+// CreateChannel creates a Channel in the Harness's namespace.
+func (h *Harness) CreateChannel(channel *channelsV1alpha1.Channel) error {
+	channel.Namespace = h.Namespace
+	channels := h.Clients.Eventing.ChannelsV1alpha1().Channels(h.Namespace)
+	if err := CreateChannel(channels, channel, h.logger, newDiscardingCleaner()); err != nil {
+		return err
+	}
+	h.record(&node{
+		ref:    objectRef{"Channel", h.Namespace, channel.Name},
+		delete: func() error { return channels.Delete(channel.Name, &metav1.DeleteOptions{}) },
+	})
+	return nil
+}
+
+// CreateSubscription creates a Subscription in the Harness's namespace. The
+// Subscription is recorded as depending on its Channel, so TearDown deletes
+// it first.
+func (h *Harness) CreateSubscription(sub *channelsV1alpha1.Subscription) error {
+	sub.Namespace = h.Namespace
+	subs := h.Clients.Eventing.ChannelsV1alpha1().Subscriptions(h.Namespace)
+	if err := CreateSubscription(subs, sub, h.logger, newDiscardingCleaner()); err != nil {
+		return err
+	}
+	h.record(&node{
+		ref:       objectRef{"Subscription", h.Namespace, sub.Name},
+		dependsOn: []objectRef{{"Channel", h.Namespace, sub.Spec.Channel}},
+		delete:    func() error { return subs.Delete(sub.Name, &metav1.DeleteOptions{}) },
+	})
+	return nil
+}
+
+// CreateFlow creates a Flow in the Harness's namespace. The Flow is recorded
+// as depending on its trigger's EventType and EventSource, so TearDown deletes
+// it before the EventType/EventSource it was fed by.
+func (h *Harness) CreateFlow(flow *flowsV1alpha1.Flow) error {
+	flow.Namespace = h.Namespace
+	flows := h.Clients.Eventing.FlowsV1alpha1().Flows(h.Namespace)
+	if err := CreateFlow(flows, flow, h.logger, newDiscardingCleaner()); err != nil {
+		return err
+	}
+	h.record(&node{
+		ref: objectRef{"Flow", h.Namespace, flow.Name},
+		dependsOn: []objectRef{
+			{"EventType", h.Namespace, flow.Spec.Trigger.EventType},
+			{"EventSource", h.Namespace, flow.Spec.Trigger.Service},
+		},
+		delete: func() error { return flows.Delete(flow.Name, &metav1.DeleteOptions{}) },
+	})
+	return nil
+}
+
+// CreateRouteAndConfig creates a Route and Configuration in the Harness's
+// namespace, serving requests from a container at imagePath. It also records
+// a VirtualService node for the Route: Knative Serving's Route reconciler
+// creates one of the same name as a side effect, and it's the VirtualService,
+// not the Route itself, that Istio is slow to tear down (see TearDown's
+// waitForVirtualServicesGone and #1376).
+func (h *Harness) CreateRouteAndConfig(name, imagePath string) error {
+	configurations := h.Clients.Serving.ServingV1alpha1().Configurations(h.Namespace)
+	config, err := configurations.Create(test.Configuration(name, h.Namespace, imagePath))
+	if err != nil {
+		return err
+	}
+	h.record(&node{
+		ref:    objectRef{"Configuration", h.Namespace, config.Name},
+		delete: func() error { return configurations.Delete(config.Name, &metav1.DeleteOptions{}) },
+	})
+
+	routes := h.Clients.Serving.ServingV1alpha1().Routes(h.Namespace)
+	route, err := routes.Create(test.Route(name, h.Namespace, name))
+	if err != nil {
+		return err
+	}
+	h.record(&node{
+		ref:       objectRef{"Route", h.Namespace, route.Name},
+		dependsOn: []objectRef{{"Configuration", h.Namespace, config.Name}},
+		delete:    func() error { return routes.Delete(route.Name, &metav1.DeleteOptions{}) },
+	})
+	h.record(&node{
+		ref:       objectRef{"VirtualService", h.Namespace, route.Name},
+		dependsOn: []objectRef{{"Route", h.Namespace, route.Name}},
+		delete: func() error {
+			return h.Clients.Istio.NetworkingV1alpha3().VirtualServices(h.Namespace).Delete(route.Name, &metav1.DeleteOptions{})
+		},
+	})
+	return nil
+}
+
+// WithRouteReady creates a Route and Configuration via CreateRouteAndConfig
+// and waits, up to the Harness's timeout, until the Route is ready.
+func (h *Harness) WithRouteReady(name, imagePath string) error {
+	if err := h.CreateRouteAndConfig(name, imagePath); err != nil {
+		return err
+	}
+	routes := h.Clients.Serving.ServingV1alpha1().Routes(h.Namespace)
+	return wait.PollImmediate(interval, h.timeout, func() (bool, error) {
+		route, err := routes.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return true, err
+		}
+		return test.IsRouteReady(route)
+	})
+}
+
+// CreateServiceAccountAndBinding creates a ServiceAccount in the Harness's
+// namespace and a cluster-scoped ClusterRoleBinding for it, recording the
+// binding as depending on the ServiceAccount.
+func (h *Harness) CreateServiceAccountAndBinding(name string) error {
+	rbac := NewRBACClient(h.Clients, h.Namespace)
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: h.Namespace}}
+	if err := CreateServiceAccount(rbac, sa, h.logger, newDiscardingCleaner()); err != nil {
+		return err
+	}
+	h.record(&node{
+		ref: objectRef{"ServiceAccount", h.Namespace, name},
+		delete: func() error {
+			return h.Clients.Kube.Kube.CoreV1().ServiceAccounts(h.Namespace).Delete(name, &metav1.DeleteOptions{})
+		},
+	})
+
+	crbName := name + "-binding"
+	crb := &rbacV1beta1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: crbName},
+		Subjects: []rbacV1beta1.Subject{
+			{Kind: "ServiceAccount", Name: name, Namespace: h.Namespace},
+		},
+		RoleRef: rbacV1beta1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin", APIGroup: "rbac.authorization.k8s.io"},
+	}
+	if err := CreateClusterRoleBinding(rbac, crb, h.logger, newDiscardingCleaner()); err != nil {
+		return err
+	}
+	h.record(&node{
+		ref:       objectRef{"ClusterRoleBinding", "", crbName},
+		dependsOn: []objectRef{{"ServiceAccount", h.Namespace, name}},
+		delete: func() error {
+			return h.Clients.Kube.Kube.RbacV1beta1().ClusterRoleBindings().Delete(crbName, &metav1.DeleteOptions{})
+		},
+	})
+	return nil
+}
+
+// newDiscardingCleaner returns a fresh *test.Cleaner for a single Create*
+// call. Harness tracks its own dependency DAG and never calls Clean() on
+// these; a previous version shared one package-level Cleaner across every
+// Harness and every call, which raced when parallel tests each called a
+// Harness method concurrently.
+func newDiscardingCleaner() *test.Cleaner {
+	return &test.Cleaner{}
+}
+
+// TearDown deletes every object the Harness created, leaves of the
+// dependency DAG first, then deletes the namespace itself. VirtualServices
+// observed during the run are deleted as a batch and polled for absence
+// instead of the package-level TearDown's unconditional 20s sleep.
+func (h *Harness) TearDown() {
+	h.mu.Lock()
+	order := topoOrder(h.nodes)
+	h.mu.Unlock()
+
+	var virtualServicesDeleted bool
+	for _, n := range order {
+		if err := n.delete(); err != nil {
+			h.logger.Infof("Error deleting %s %s/%s: %v", n.ref.kind, n.ref.namespace, n.ref.name, err)
+		}
+		if n.ref.kind == "VirtualService" {
+			virtualServicesDeleted = true
+		}
+	}
+
+	if virtualServicesDeleted {
+		h.waitForVirtualServicesGone()
+	}
+
+	if err := h.Clients.Kube.Kube.CoreV1().Namespaces().Delete(h.Namespace, &metav1.DeleteOptions{}); err != nil {
+		h.logger.Infof("Error deleting namespace %s: %v", h.Namespace, err)
+	}
+}
+
+// waitForVirtualServicesGone polls for the absence of any VirtualService in
+// the Harness's namespace rather than sleeping a fixed 20s, so scenarios
+// that never created one (or whose Istio sidecar settles quickly) don't pay
+// for the #1376 workaround at all.
+func (h *Harness) waitForVirtualServicesGone() {
+	deadline := istioDeleteGrace
+	if err := wait.PollImmediate(interval, deadline, func() (bool, error) {
+		list, err := h.Clients.Istio.NetworkingV1alpha3().VirtualServices(h.Namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		return len(list.Items) == 0, nil
+	}); err != nil {
+		h.logger.Infof("VirtualServices in %s did not disappear within %s after delete: %v", h.Namespace, deadline, err)
+	}
+}
+
+// topoOrder returns nodes ordered so that every node appears after
+// everything it dependsOn (a dependency-first / leaf-last build order), then
+// reverses it so TearDown deletes leaves first.
+func topoOrder(nodes map[objectRef]*node) []*node {
+	visited := map[objectRef]bool{}
+	var order []*node
+
+	var visit func(ref objectRef)
+	visit = func(ref objectRef) {
+		if visited[ref] {
+			return
+		}
+		visited[ref] = true
+		n, ok := nodes[ref]
+		if !ok {
+			return
+		}
+		for _, dep := range n.dependsOn {
+			visit(dep)
+		}
+		order = append(order, n)
+	}
+	for ref := range nodes {
+		visit(ref)
+	}
+
+	// order is currently dependency-first (Channels before their
+	// Subscriptions); TearDown wants the opposite.
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
@@ -0,0 +1,50 @@
+package e2e
+
+import (
+	"testing"
+)
+
+func TestTopoOrder(t *testing.T) {
+	channel := objectRef{"Channel", "ns", "the-channel"}
+	subscription := objectRef{"Subscription", "ns", "the-subscription"}
+	serviceAccount := objectRef{"ServiceAccount", "ns", "the-sa"}
+	binding := objectRef{"ClusterRoleBinding", "", "the-binding"}
+
+	nodes := map[objectRef]*node{
+		channel:        {ref: channel},
+		subscription:   {ref: subscription, dependsOn: []objectRef{channel}},
+		serviceAccount: {ref: serviceAccount},
+		binding:        {ref: binding, dependsOn: []objectRef{serviceAccount}},
+	}
+
+	order := topoOrder(nodes)
+	if len(order) != len(nodes) {
+		t.Fatalf("got %d nodes, want %d", len(order), len(nodes))
+	}
+
+	index := map[objectRef]int{}
+	for i, n := range order {
+		index[n.ref] = i
+	}
+
+	if index[subscription] >= index[channel] {
+		t.Errorf("Subscription (leaf) must come before its Channel in teardown order, got subscription=%d channel=%d", index[subscription], index[channel])
+	}
+	if index[binding] >= index[serviceAccount] {
+		t.Errorf("ClusterRoleBinding (leaf) must come before its ServiceAccount in teardown order, got binding=%d serviceAccount=%d", index[binding], index[serviceAccount])
+	}
+}
+
+func TestTopoOrderIgnoresDanglingDependency(t *testing.T) {
+	// A node depending on a ref that was never recorded (e.g. created
+	// outside the Harness) shouldn't panic or get dropped.
+	orphan := objectRef{"Subscription", "ns", "orphan"}
+	nodes := map[objectRef]*node{
+		orphan: {ref: orphan, dependsOn: []objectRef{{"Channel", "ns", "missing"}}},
+	}
+
+	order := topoOrder(nodes)
+	if len(order) != 1 || order[0].ref != orphan {
+		t.Fatalf("got %v, want a single node %v", order, orphan)
+	}
+}
@@ -0,0 +1,77 @@
+package e2e
+
+import (
+	channelsV1alpha1 "github.com/knative/eventing/pkg/apis/channels/v1alpha1"
+	feedsV1alpha1 "github.com/knative/eventing/pkg/apis/feeds/v1alpha1"
+	flowsV1alpha1 "github.com/knative/eventing/pkg/apis/flows/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacV1beta1 "k8s.io/api/rbac/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The interfaces below are the seam between this package's Create* helpers
+// and the concrete *test.Clients used against a live cluster. Each is just
+// the subset of the generated clientset's namespaced interface that the
+// corresponding helper (and test.Cleaner, which only ever calls Delete)
+// actually needs. Splitting these out, rather than threading *test.Clients
+// everywhere, is what lets fake.Clients back these same helpers with
+// client-go/testing.Fake in controller and reconciliation tests.
+
+// FlowClient is the subset of FlowsV1alpha1Interface.Flows(ns) CreateFlow needs.
+type FlowClient interface {
+	Create(*flowsV1alpha1.Flow) (*flowsV1alpha1.Flow, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+}
+
+// ChannelClient is the subset of ChannelsV1alpha1Interface.Channels(ns)
+// CreateChannel needs.
+type ChannelClient interface {
+	Create(*channelsV1alpha1.Channel) (*channelsV1alpha1.Channel, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+}
+
+// SubscriptionClient is the subset of
+// ChannelsV1alpha1Interface.Subscriptions(ns) CreateSubscription needs.
+type SubscriptionClient interface {
+	Create(*channelsV1alpha1.Subscription) (*channelsV1alpha1.Subscription, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+}
+
+// FeedClient is the subset of FeedsV1alpha1Interface that CreateEventSource
+// and CreateEventType need, bundled into one interface since both helpers
+// operate on the same namespace's feeds client.
+type FeedClient interface {
+	CreateEventSource(*feedsV1alpha1.EventSource) (*feedsV1alpha1.EventSource, error)
+	DeleteEventSource(name string, options *metav1.DeleteOptions) error
+	CreateEventType(*feedsV1alpha1.EventType) (*feedsV1alpha1.EventType, error)
+	DeleteEventType(name string, options *metav1.DeleteOptions) error
+}
+
+// PodClient is the subset of CoreV1Interface.Pods(ns) CreatePod and PodLogs
+// need.
+type PodClient interface {
+	Create(*corev1.Pod) (*corev1.Pod, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	List(opts metav1.ListOptions) (*corev1.PodList, error)
+	GetLogs(name string, opts *corev1.PodLogOptions) RawResult
+}
+
+// RawResult mirrors the subset of rest.Result the real PodClient.GetLogs
+// call returns that WaitForLogContent actually reads. It's exported so
+// fake.Clients' PodClient adapter can implement GetLogs without depending on
+// an unexported type.
+type RawResult interface {
+	Raw() ([]byte, error)
+}
+
+// RBACClient is the subset of CoreV1Interface.ServiceAccounts(ns) and
+// RbacV1beta1Interface.ClusterRoleBindings() that CreateServiceAccount and
+// CreateClusterRoleBinding need, including their Delete methods so
+// test.Cleaner can be wired up the same way CreateEventSource/CreateEventType
+// wire up FeedClient (see eventSourceDeleter/eventTypeDeleter).
+type RBACClient interface {
+	CreateServiceAccount(*corev1.ServiceAccount) (*corev1.ServiceAccount, error)
+	DeleteServiceAccount(name string, options *metav1.DeleteOptions) error
+	CreateClusterRoleBinding(*rbacV1beta1.ClusterRoleBinding) (*rbacV1beta1.ClusterRoleBinding, error)
+	DeleteClusterRoleBinding(name string, options *metav1.DeleteOptions) error
+}
@@ -0,0 +1,22 @@
+package e2e
+
+import (
+	"context"
+
+	"github.com/knative/eventing/pkg/analysis"
+	"github.com/knative/eventing/test"
+)
+
+// RunPreflight fans out the analyzers registered in analysis.DefaultRegistry
+// over the given namespace and returns any Messages found. Run this before a
+// scenario that relies on a non-trivial chain of objects (e.g. a Flow that
+// transitively depends on a Channel's ClusterProvisioner and a Feed's RBAC)
+// so test failures point at the actual misconfiguration instead of a raw
+// WaitForFlowState timeout.
+func RunPreflight(clients *test.Clients, namespace string) []analysis.Message {
+	snapshot := &analysis.Snapshot{
+		Clients:   clients,
+		Namespace: namespace,
+	}
+	return analysis.DefaultRegistry.Run(context.Background(), snapshot)
+}
@@ -0,0 +1,274 @@
+package e2e
+
+import (
+	"fmt"
+	"time"
+
+	channelsV1alpha1 "github.com/knative/eventing/pkg/apis/channels/v1alpha1"
+	eventingv1alpha1 "github.com/knative/eventing/pkg/apis/eventing/v1alpha1"
+	feedsV1alpha1 "github.com/knative/eventing/pkg/apis/feeds/v1alpha1"
+	flowsV1alpha1 "github.com/knative/eventing/pkg/apis/flows/v1alpha1"
+	eventingscheme "github.com/knative/eventing/pkg/client/clientset/versioned/scheme"
+	"github.com/knative/eventing/test"
+	duckv1alpha1 "github.com/knative/pkg/apis/duck/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// resourceScheme is the scheme WaitForResourceReady dispatches GVKs against.
+// k8s.io/client-go/kubernetes/scheme.Scheme only knows about built-in types,
+// so none of our duck-typed CRDs (Channel, Subscription, ClusterProvisioner,
+// Flow, Feed) or the CRD type itself would ever resolve through it. The
+// generated eventing clientset's own scheme package registers only our CRDs
+// (see its localSchemeBuilder), so this scheme folds in the built-ins, our
+// CRDs, and apiextensions for the CRD checker.
+var resourceScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(kubescheme.AddToScheme(resourceScheme))
+	utilruntime.Must(eventingscheme.AddToScheme(resourceScheme))
+	utilruntime.Must(apiextensionsv1beta1.AddToScheme(resourceScheme))
+}
+
+// readyChecker reports whether obj has reached its terminal ready state.
+// done is true once the object will never change its ready-ness again,
+// either because it became ready or because it reached a failure state that
+// polling further won't fix.
+type readyChecker func(obj runtime.Object) (ready bool, done bool, err error)
+
+// readyCheckers is the dispatch table of readyChecker funcs keyed by the GVK
+// of the object they know how to evaluate. New eventing CRDs register their
+// own checker here via RegisterReadyChecker, so WaitForResourceReady can wait
+// on mixed resource sets (e.g. the Deployments, Services and Channels a Flow
+// transitively creates) without a switch statement growing forever.
+var readyCheckers = map[schema.GroupVersionKind]readyChecker{}
+
+// RegisterReadyChecker registers a readyChecker for the given GVK, replacing
+// any previously registered checker for that GVK.
+func RegisterReadyChecker(gvk schema.GroupVersionKind, check readyChecker) {
+	readyCheckers[gvk] = check
+}
+
+func init() {
+	RegisterReadyChecker(appsv1.SchemeGroupVersion.WithKind("Deployment"), deploymentReady)
+	RegisterReadyChecker(appsv1.SchemeGroupVersion.WithKind("StatefulSet"), statefulSetReady)
+	RegisterReadyChecker(appsv1.SchemeGroupVersion.WithKind("DaemonSet"), daemonSetReady)
+	RegisterReadyChecker(corev1.SchemeGroupVersion.WithKind("Pod"), podReady)
+	RegisterReadyChecker(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"), pvcReady)
+	RegisterReadyChecker(corev1.SchemeGroupVersion.WithKind("Service"), serviceReady)
+	RegisterReadyChecker(apiextensionsv1beta1.SchemeGroupVersion.WithKind("CustomResourceDefinition"), crdReady)
+
+	RegisterReadyChecker(channelsV1alpha1.SchemeGroupVersion.WithKind("Channel"), channelReady)
+	RegisterReadyChecker(channelsV1alpha1.SchemeGroupVersion.WithKind("Subscription"), subscriptionReady)
+	RegisterReadyChecker(eventingv1alpha1.SchemeGroupVersion.WithKind("ClusterProvisioner"), clusterProvisionerReady)
+	RegisterReadyChecker(flowsV1alpha1.SchemeGroupVersion.WithKind("Flow"), flowReady)
+	RegisterReadyChecker(feedsV1alpha1.SchemeGroupVersion.WithKind("Feed"), feedReady)
+}
+
+// WaitForResourceReady polls obj until its registered readyChecker reports
+// ready, a terminal failure is observed, or timeout elapses. obj must
+// already exist; its current state is refreshed from clients on every poll.
+// Kinds without a registered readyChecker are treated as immediately ready,
+// matching Helm 3's "we don't know how to check this, so don't block on it"
+// behavior.
+func WaitForResourceReady(clients *test.Clients, obj runtime.Object, timeout time.Duration) error {
+	gvk, err := gvkOf(obj)
+	if err != nil {
+		return err
+	}
+	check, ok := readyCheckers[gvk]
+	if !ok {
+		return nil
+	}
+	name := metaOf(obj).GetName()
+	return wait.PollImmediate(interval, timeout, func() (bool, error) {
+		current, err := refresh(clients, gvk, obj)
+		if err != nil {
+			return false, err
+		}
+		ready, done, err := check(current)
+		if done && err != nil {
+			return false, fmt.Errorf("%s %q will never become ready: %v", gvk.Kind, name, err)
+		}
+		return ready, nil
+	})
+}
+
+// WaitForResourceDeleted polls until obj can no longer be found, or timeout
+// elapses.
+func WaitForResourceDeleted(clients *test.Clients, obj runtime.Object, timeout time.Duration) error {
+	gvk, err := gvkOf(obj)
+	if err != nil {
+		return err
+	}
+	return wait.PollImmediate(interval, timeout, func() (bool, error) {
+		if _, err := refresh(clients, gvk, obj); err != nil {
+			if apierrs.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return false, nil
+	})
+}
+
+// refresh re-fetches obj by namespace/name through the dynamic client so
+// WaitForResourceReady can dispatch on arbitrary, including duck-typed, GVKs
+// without a typed client for each one.
+func refresh(clients *test.Clients, gvk schema.GroupVersionKind, obj runtime.Object) (runtime.Object, error) {
+	objMeta := metaOf(obj)
+	gvr, _ := apimeta.UnsafeGuessKindToResource(gvk)
+	u, err := clients.Dynamic.Resource(gvr).Namespace(objMeta.GetNamespace()).Get(objMeta.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	out := obj.DeepCopyObject()
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.(*unstructured.Unstructured).Object, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func deploymentReady(obj runtime.Object) (bool, bool, error) {
+	d := obj.(*appsv1.Deployment)
+	if d.Status.ObservedGeneration < d.Generation {
+		return false, false, nil
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	progressing := true
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status != corev1.ConditionTrue {
+			progressing = false
+		}
+	}
+	return d.Status.AvailableReplicas >= replicas && progressing, false, nil
+}
+
+func statefulSetReady(obj runtime.Object) (bool, bool, error) {
+	ss := obj.(*appsv1.StatefulSet)
+	replicas := int32(1)
+	if ss.Spec.Replicas != nil {
+		replicas = *ss.Spec.Replicas
+	}
+	ready := ss.Status.UpdatedReplicas == replicas && ss.Status.CurrentRevision == ss.Status.UpdateRevision
+	return ready, false, nil
+}
+
+func daemonSetReady(obj runtime.Object) (bool, bool, error) {
+	ds := obj.(*appsv1.DaemonSet)
+	return ds.Status.NumberReady == ds.Status.DesiredNumberScheduled, false, nil
+}
+
+func podReady(obj runtime.Object) (bool, bool, error) {
+	pod := obj.(*corev1.Pod)
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, true, nil
+	case corev1.PodFailed:
+		return false, true, fmt.Errorf("pod failed: %s", pod.Status.Reason)
+	case corev1.PodRunning:
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady {
+				return c.Status == corev1.ConditionTrue, false, nil
+			}
+		}
+	}
+	return false, false, nil
+}
+
+func pvcReady(obj runtime.Object) (bool, bool, error) {
+	pvc := obj.(*corev1.PersistentVolumeClaim)
+	return pvc.Status.Phase == corev1.ClaimBound, false, nil
+}
+
+func serviceReady(obj runtime.Object) (bool, bool, error) {
+	svc := obj.(*corev1.Service)
+	switch svc.Spec.Type {
+	case corev1.ServiceTypeExternalName:
+		return true, false, nil
+	case corev1.ServiceTypeLoadBalancer:
+		return len(svc.Status.LoadBalancer.Ingress) > 0, false, nil
+	default:
+		return svc.Spec.ClusterIP != "", false, nil
+	}
+}
+
+func crdReady(obj runtime.Object) (bool, bool, error) {
+	crd := obj.(*apiextensionsv1beta1.CustomResourceDefinition)
+	established, accepted := false, false
+	for _, c := range crd.Status.Conditions {
+		switch c.Type {
+		case apiextensionsv1beta1.Established:
+			established = c.Status == apiextensionsv1beta1.ConditionTrue
+		case apiextensionsv1beta1.NamesAccepted:
+			accepted = c.Status == apiextensionsv1beta1.ConditionTrue
+		}
+	}
+	return established && accepted, false, nil
+}
+
+// channelReady and its siblings below evaluate the Ready condition (or, for
+// Channel, the ChannelConditionReady alias used throughout this codebase) of
+// our own duck-typed CRDs. They return done=true on any ConditionFalse so
+// callers don't keep polling a Channel/Flow/etc. that has already failed;
+// the condition's Reason is not examined, so a False that would later clear
+// also ends the wait.
+func channelReady(obj runtime.Object) (bool, bool, error) {
+	return duckConditionReady(obj.(*channelsV1alpha1.Channel).Status.GetCondition(channelsV1alpha1.ChannelConditionReady))
+}
+
+func subscriptionReady(obj runtime.Object) (bool, bool, error) {
+	return duckConditionReady(obj.(*channelsV1alpha1.Subscription).Status.GetCondition(duckv1alpha1.ConditionReady))
+}
+
+func clusterProvisionerReady(obj runtime.Object) (bool, bool, error) {
+	return duckConditionReady(obj.(*eventingv1alpha1.ClusterProvisioner).Status.GetCondition(duckv1alpha1.ConditionReady))
+}
+
+func flowReady(obj runtime.Object) (bool, bool, error) {
+	return duckConditionReady(obj.(*flowsV1alpha1.Flow).Status.GetCondition(duckv1alpha1.ConditionReady))
+}
+
+func feedReady(obj runtime.Object) (bool, bool, error) {
+	return duckConditionReady(obj.(*feedsV1alpha1.Feed).Status.GetCondition(duckv1alpha1.ConditionReady))
+}
+
+func duckConditionReady(cond *duckv1alpha1.Condition) (bool, bool, error) {
+	if cond == nil {
+		return false, false, nil
+	}
+	switch cond.Status {
+	case corev1.ConditionTrue:
+		return true, true, nil
+	case corev1.ConditionFalse:
+		return false, true, fmt.Errorf("%s: %s", cond.Reason, cond.Message)
+	default:
+		return false, false, nil
+	}
+}
+
+func gvkOf(obj runtime.Object) (schema.GroupVersionKind, error) {
+	gvks, _, err := resourceScheme.ObjectKinds(obj)
+	if err != nil || len(gvks) == 0 {
+		return schema.GroupVersionKind{}, fmt.Errorf("could not determine GVK for %T: %v", obj, err)
+	}
+	return gvks[0], nil
+}
+
+func metaOf(obj runtime.Object) metav1.Object {
+	accessor, _ := apimeta.Accessor(obj)
+	return accessor
+}
@@ -0,0 +1,162 @@
+package e2e
+
+import (
+	"testing"
+
+	eventingv1alpha1 "github.com/knative/eventing/pkg/apis/eventing/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func TestDeploymentReady(t *testing.T) {
+	tests := []struct {
+		name string
+		dep  *appsv1.Deployment
+		want bool
+	}{{
+		name: "observed generation behind",
+		dep: &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(1)},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, AvailableReplicas: 1},
+		},
+		want: false,
+	}, {
+		name: "available replicas short",
+		dep: &appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			Status: appsv1.DeploymentStatus{AvailableReplicas: 1},
+		},
+		want: false,
+	}, {
+		name: "progressing false",
+		dep: &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{Replicas: int32Ptr(1)},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: 1,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse},
+				},
+			},
+		},
+		want: false,
+	}, {
+		name: "ready",
+		dep: &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: 2,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue},
+				},
+			},
+		},
+		want: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, _, err := deploymentReady(test.dep)
+			if err != nil {
+				t.Fatalf("deploymentReady returned unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("deploymentReady() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPodReady(t *testing.T) {
+	tests := []struct {
+		name      string
+		pod       *corev1.Pod
+		wantReady bool
+		wantDone  bool
+		wantErr   bool
+	}{{
+		name:      "succeeded is terminally ready",
+		pod:       &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+		wantReady: true,
+		wantDone:  true,
+	}, {
+		name:     "failed is terminal and not ready",
+		pod:      &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"}},
+		wantDone: true,
+		wantErr:  true,
+	}, {
+		name: "running and ready condition true",
+		pod: &corev1.Pod{Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		}},
+		wantReady: true,
+	}, {
+		name: "running but not yet ready",
+		pod: &corev1.Pod{Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+		}},
+		wantReady: false,
+	}, {
+		name: "pending",
+		pod:  &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending}},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ready, done, err := podReady(test.pod)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("podReady() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if ready != test.wantReady {
+				t.Errorf("podReady() ready = %v, want %v", ready, test.wantReady)
+			}
+			if done != test.wantDone {
+				t.Errorf("podReady() done = %v, want %v", done, test.wantDone)
+			}
+		})
+	}
+}
+
+// TestReadyCheckersRegistered guards the init() dispatch table itself: a typo
+// in a GVK or a checker that's dropped during a refactor would otherwise only
+// surface as WaitForResourceReady silently treating that kind as always-ready
+// (see its documented fallback behavior) instead of a test failure. It checks
+// Kind only, since the exact Group each CRD registers under lives in packages
+// this test doesn't otherwise depend on.
+func TestReadyCheckersRegistered(t *testing.T) {
+	for _, kind := range []string{
+		"Deployment", "StatefulSet", "DaemonSet",
+		"Pod", "PersistentVolumeClaim", "Service",
+		"CustomResourceDefinition",
+		"Channel", "Subscription", "ClusterProvisioner", "Flow", "Feed",
+	} {
+		found := false
+		for k := range readyCheckers {
+			if k.Kind == kind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("no readyChecker registered for Kind %q", kind)
+		}
+	}
+}
+
+// TestClusterProvisionerReadyCheckerGroup guards the specific mismatch that
+// slipped past TestReadyCheckersRegistered's Kind-only check: ClusterProvisioner
+// is a type in pkg/apis/eventing/v1alpha1, not pkg/apis/channels/v1alpha1, so a
+// checker registered under the wrong package's SchemeGroupVersion would never
+// be found by gvkOf's lookup for a real *eventingv1alpha1.ClusterProvisioner,
+// and WaitForResourceReady would silently skip the ready check entirely.
+func TestClusterProvisionerReadyCheckerGroup(t *testing.T) {
+	want := eventingv1alpha1.SchemeGroupVersion.WithKind("ClusterProvisioner")
+	if _, ok := readyCheckers[want]; !ok {
+		t.Errorf("no readyChecker registered for %v", want)
+	}
+}
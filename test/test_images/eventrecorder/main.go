@@ -0,0 +1,74 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// eventrecorder is a Flow target used by e2e tests. It accepts CloudEvents
+// over HTTP, keeps the last bufferSize of them in memory, and exposes them
+// over GET /events so tests can assert on ordering, dedup, and delivery
+// counts without scraping pod logs.
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+)
+
+const bufferSize = 1000
+
+type ringBuffer struct {
+	mu     sync.Mutex
+	events []json.RawMessage
+}
+
+func (r *ringBuffer) add(event json.RawMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	if len(r.events) > bufferSize {
+		r.events = r.events[len(r.events)-bufferSize:]
+	}
+}
+
+func (r *ringBuffer) snapshot() []json.RawMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]json.RawMessage, len(r.events))
+	copy(out, r.events)
+	return out
+}
+
+func main() {
+	buffer := &ringBuffer{}
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		var event json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		buffer.add(event)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	http.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(buffer.snapshot())
+	})
+
+	log.Print("eventrecorder listening on :8080")
+	log.Fatal(http.ListenAndServe(":8080", nil))
+}